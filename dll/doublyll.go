@@ -23,6 +23,11 @@ type Entry[K comparable, V any] struct {
 
 	// The expiry bucket item was put in (optional).
 	ExpireBucket uint8
+
+	// The generation of the owning cache this entry was created in. Used by
+	// callers that invalidate entries in bulk (e.g. Purge) without walking
+	// the list (optional).
+	Generation int64
 }
 
 // LruList represents a doubly linked list. The zero value for LruList is an
@@ -0,0 +1,324 @@
+// Package expirable provides a thread-safe LRU cache where every entry
+// carries its own time-to-live. It keeps its own list and map on top of the
+// doubly linked list in dll and does not use simplelru.
+package expirable
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/srivastavcodes/gocache/dll"
+)
+
+// numBuckets is the number of expiry buckets the background sweeper cycles
+// through. An entry lands in the bucket furthest from the one currently
+// being swept, so the sweeper only ever has to look at 1/numBuckets of the
+// cache on a given tick instead of walking every entry.
+const numBuckets = 100
+
+// EvictCallback is used to get a callback when a cache entry is evicted.
+type EvictCallback[K comparable, V any] func(key K, val V)
+
+// Cache is a thread-safe fixed size LRU cache where every entry has its own
+// expiry. Expired entries are removed lazily on Get/Peek and proactively by
+// a background sweeper.
+type Cache[K comparable, V any] struct {
+	mu sync.Mutex
+
+	size      int
+	ttl       time.Duration
+	evictList *dll.LruList[K, V]
+	items     map[K]*dll.Entry[K, V]
+	onEvict   EvictCallback[K, V]
+
+	buckets   [numBuckets]bucket[K, V]
+	nowBucket uint8
+
+	done chan struct{}
+}
+
+// bucket groups entries that the sweeper will consider expired in the same
+// pass.
+type bucket[K comparable, V any] struct {
+	entries map[K]*dll.Entry[K, V]
+}
+
+// NewWithTTL initializes a fixed size cache with the given eviction callback
+// and default entry TTL.
+func NewWithTTL[K comparable, V any](size int, onEvict EvictCallback[K, V], ttl time.Duration) (*Cache[K, V], error) {
+	if size <= 0 {
+		return nil, errors.New("size must be greater than 0")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("ttl must be greater than 0")
+	}
+	c := &Cache[K, V]{
+		size:      size,
+		ttl:       ttl,
+		evictList: dll.NewList[K, V](),
+		items:     make(map[K]*dll.Entry[K, V]),
+		onEvict:   onEvict,
+		done:      make(chan struct{}),
+	}
+	for i := range c.buckets {
+		c.buckets[i].entries = make(map[K]*dll.Entry[K, V])
+	}
+	interval := ttl / numBuckets
+	if interval <= 0 {
+		// ttl is smaller than numBuckets nanoseconds; a zero or negative
+		// interval would make time.NewTicker panic.
+		interval = time.Nanosecond
+	}
+	go c.sweep(interval)
+	runtime.SetFinalizer(c, func(c *Cache[K, V]) {
+		close(c.done)
+	})
+	return c, nil
+}
+
+// sweep runs DeleteExpired on the given interval until the cache is
+// finalized, at which point done is closed and the goroutine exits so it
+// does not pin the cache in memory.
+func (c *Cache[K, V]) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// Add adds a key-val pair to the cache with the default TTL. Returns true if
+// an eviction occurred.
+func (c *Cache[K, V]) Add(key K, val V) bool {
+	return c.AddWithTTL(key, val, c.ttl)
+}
+
+// AddWithTTL adds a key-val pair to the cache that expires after ttl.
+// Returns true if an eviction occurred.
+func (c *Cache[K, V]) AddWithTTL(key K, val V, ttl time.Duration) bool {
+	c.mu.Lock()
+
+	now := time.Now()
+	if entry, ok := c.items[key]; ok {
+		c.removeFromBucket(entry)
+		c.evictList.MoveToFront(entry)
+		entry.Val = val
+		entry.ExpiresAt = now.Add(ttl)
+		c.addToBucket(entry)
+		c.mu.Unlock()
+		return false
+	}
+	entry := c.evictList.PushFrontExpirable(key, val, now.Add(ttl))
+	c.items[key] = entry
+	c.addToBucket(entry)
+
+	var evictKey K
+	var evictVal V
+	evict := c.evictList.Length() > c.size
+	if evict {
+		evictKey, evictVal, _ = c.removeOldest()
+	}
+	c.mu.Unlock()
+
+	if evict && c.onEvict != nil {
+		c.onEvict(evictKey, evictVal)
+	}
+	return evict
+}
+
+// bucketIndex returns the bucket a freshly-inserted entry should live in:
+// the one furthest from the bucket the sweeper is currently processing.
+func (c *Cache[K, V]) bucketIndex() uint8 {
+	return uint8((int(c.nowBucket) + numBuckets - 1) % numBuckets)
+}
+
+// addToBucket records entry's bucket assignment and adds it to that bucket.
+func (c *Cache[K, V]) addToBucket(entry *dll.Entry[K, V]) {
+	idx := c.bucketIndex()
+	entry.ExpireBucket = idx
+	c.buckets[idx].entries[entry.Key] = entry
+}
+
+// removeFromBucket removes entry from the bucket it was last assigned to.
+func (c *Cache[K, V]) removeFromBucket(entry *dll.Entry[K, V]) {
+	delete(c.buckets[entry.ExpireBucket].entries, entry.Key)
+}
+
+// Get returns the value and true for the given key if it exists and has not
+// expired, refreshing its recentness. Expired entries are evicted and
+// treated as missing.
+func (c *Cache[K, V]) Get(key K) (val V, ok bool) {
+	c.mu.Lock()
+
+	entry, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return val, false
+	}
+	if c.expired(entry) {
+		evictKey, evictVal := c.removeElement(entry)
+		c.mu.Unlock()
+		if c.onEvict != nil {
+			c.onEvict(evictKey, evictVal)
+		}
+		return val, false
+	}
+	c.evictList.MoveToFront(entry)
+	val = entry.Val
+	c.mu.Unlock()
+	return val, true
+}
+
+// Peek returns the value for the given key without updating its recentness.
+// Expired entries are evicted and treated as missing.
+func (c *Cache[K, V]) Peek(key K) (val V, ok bool) {
+	c.mu.Lock()
+
+	entry, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return val, false
+	}
+	if c.expired(entry) {
+		evictKey, evictVal := c.removeElement(entry)
+		c.mu.Unlock()
+		if c.onEvict != nil {
+			c.onEvict(evictKey, evictVal)
+		}
+		return val, false
+	}
+	val = entry.Val
+	c.mu.Unlock()
+	return val, true
+}
+
+// Contains returns true if the key exists in the cache and has not expired,
+// without updating its recentness.
+func (c *Cache[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	return !c.expired(entry)
+}
+
+// Remove removes the key from the cache if it exists. Returns whether the
+// key was present.
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+
+	entry, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return false
+	}
+	evictKey, evictVal := c.removeElement(entry)
+	c.mu.Unlock()
+
+	if c.onEvict != nil {
+		c.onEvict(evictKey, evictVal)
+	}
+	return true
+}
+
+// Keys returns a slice of the non-expired keys in the cache, from oldest to
+// newest.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, c.evictList.Length())
+	now := time.Now()
+	for entry := c.evictList.Back(); entry != nil; entry = entry.PrevEntry() {
+		if !entry.ExpiresAt.IsZero() && now.After(entry.ExpiresAt) {
+			continue
+		}
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
+
+// Len returns the number of non-expired items in the cache.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	count := 0
+	for entry := c.evictList.Back(); entry != nil; entry = entry.PrevEntry() {
+		if entry.ExpiresAt.IsZero() || !now.After(entry.ExpiresAt) {
+			count++
+		}
+	}
+	return count
+}
+
+// Cap returns the capacity of the cache.
+func (c *Cache[K, V]) Cap() int {
+	return c.size
+}
+
+// DeleteExpired sweeps the bucket due for cleanup on this tick, evicting
+// every entry whose TTL has elapsed, then advances to the next bucket.
+func (c *Cache[K, V]) DeleteExpired() {
+	c.mu.Lock()
+	idx := c.nowBucket
+	c.nowBucket = uint8((int(c.nowBucket) + 1) % numBuckets)
+
+	now := time.Now()
+	var keys []K
+	var vals []V
+	for _, entry := range c.buckets[idx].entries {
+		if !now.After(entry.ExpiresAt) {
+			continue
+		}
+		k, v := c.removeElement(entry)
+		if c.onEvict != nil {
+			keys = append(keys, k)
+			vals = append(vals, v)
+		}
+	}
+	c.mu.Unlock()
+
+	if c.onEvict != nil {
+		for i := range keys {
+			c.onEvict(keys[i], vals[i])
+		}
+	}
+}
+
+// expired reports whether entry's TTL has elapsed. Callers must hold c.mu.
+func (c *Cache[K, V]) expired(entry *dll.Entry[K, V]) bool {
+	return !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt)
+}
+
+// removeOldest removes the oldest entry from the cache and returns its
+// key/val, or zero values and false if the cache is empty. Callers must hold
+// c.mu.
+func (c *Cache[K, V]) removeOldest() (key K, val V, ok bool) {
+	if entry := c.evictList.Back(); entry != nil {
+		key, val = c.removeElement(entry)
+		return key, val, true
+	}
+	return key, val, false
+}
+
+// removeElement removes the given entry from the cache and returns its
+// key/val. The caller is responsible for firing the onEvict callback once
+// c.mu has been released. Callers must hold c.mu.
+func (c *Cache[K, V]) removeElement(entry *dll.Entry[K, V]) (K, V) {
+	c.evictList.Remove(entry)
+	delete(c.items, entry.Key)
+	c.removeFromBucket(entry)
+	return entry.Key, entry.Val
+}
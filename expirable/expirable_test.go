@@ -0,0 +1,94 @@
+package expirable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	cache, err := NewWithTTL[int, int](2, nil, time.Hour)
+	require.NoError(t, err)
+
+	cache.Add(1, 1)
+	cache.Add(2, 2)
+	val, ok := cache.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 1, val)
+
+	cache.Add(3, 3) // evicts 2, the least recently used after Get(1)
+	_, ok = cache.Get(2)
+	require.False(t, ok, "should have been evicted")
+
+	val, ok = cache.Peek(3)
+	require.True(t, ok)
+	require.Equal(t, 3, val)
+
+	require.True(t, cache.Remove(3))
+	require.False(t, cache.Remove(3))
+	require.Equal(t, 1, cache.Len())
+}
+
+func TestCache_Expiry(t *testing.T) {
+	cache, err := NewWithTTL[string, int](2, nil, 20*time.Millisecond)
+	require.NoError(t, err)
+
+	cache.AddWithTTL("short", 1, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	_, ok := cache.Get("short")
+	require.False(t, ok, "expired entry should be treated as missing on Get")
+
+	cache.AddWithTTL("alive", 2, time.Hour)
+	require.True(t, cache.Contains("alive"))
+}
+
+func TestCache_DeleteExpired(t *testing.T) {
+	var evicted []string
+	onEvict := func(key string, _ int) {
+		evicted = append(evicted, key)
+	}
+	// A long default TTL keeps the background sweeper from racing with the
+	// explicit DeleteExpired calls below; AddWithTTL overrides it per entry.
+	cache, err := NewWithTTL[string, int](10, onEvict, time.Hour)
+	require.NoError(t, err)
+
+	cache.AddWithTTL("a", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	// The entry may land in any of the numBuckets buckets, so sweep a full
+	// cycle to guarantee its bucket gets visited.
+	for i := 0; i < numBuckets; i++ {
+		cache.DeleteExpired()
+	}
+
+	require.Contains(t, evicted, "a")
+	require.Zero(t, cache.Len())
+}
+
+func TestCache_ReentrantEvictCallback(t *testing.T) {
+	var cache *Cache[int, int]
+	onEvict := func(key, val int) {
+		cache.Add(0, val)
+	}
+	var err error
+	cache, err = NewWithTTL[int, int](2, onEvict, time.Hour)
+	require.NoError(t, err)
+	cache.Add(0, 0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.Add(1, 1)
+		cache.Get(0)
+		cache.Add(2, 2)
+		cache.Remove(2)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("deadlock detected: a mutating Cache method did not return")
+	}
+}
@@ -0,0 +1,307 @@
+// Package go2q provides a scan-resistant 2Q cache built on top of the
+// simplelru primitives used by the rest of this module.
+package go2q
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/srivastavcodes/gocache/simplelru"
+)
+
+const (
+	// Recent2QRatio is the default fraction of the total size given to the
+	// recent queue, which holds entries seen only once.
+	Recent2QRatio = 0.25
+	// Ghost2QRatio is the default fraction of the total size given to the
+	// ghost list of keys recently evicted from the recent queue.
+	Ghost2QRatio = 0.50
+)
+
+// TwoQueueCache is a thread-safe fixed size cache implementing the 2Q
+// admission policy: entries seen once live in recent, entries seen a second
+// time are promoted to frequent, and keys recently evicted from recent are
+// kept as ghost entries in recentEvict so a second access can promote
+// straight to frequent instead of restarting in recent. This makes the
+// cache resistant to one-off scans that would otherwise thrash a plain LRU.
+type TwoQueueCache[K comparable, V any] struct {
+	rwm sync.RWMutex
+
+	size       int
+	recentSize int
+
+	recent      *simplelru.LruCache[K, V]
+	frequent    *simplelru.LruCache[K, V]
+	recentEvict *simplelru.LruCache[K, struct{}]
+
+	// buffers to store evicted key/val pairs.
+	// used when onEvictCb is provided.
+	keysEvicted []K
+	valsEvicted []V
+
+	onEvictCb func(key K, val V)
+}
+
+// New2Q creates a 2Q cache of the given size.
+func New2Q[K comparable, V any](size int) (*TwoQueueCache[K, V], error) {
+	return New2QWithEvict[K, V](size, nil)
+}
+
+// New2QWithEvict initializes a fixed size 2Q cache with the given eviction
+// callback.
+func New2QWithEvict[K comparable, V any](size int, onEvict func(key K, val V)) (*TwoQueueCache[K, V], error) {
+	if size <= 0 {
+		return nil, errors.New("size must be greater than 0")
+	}
+	recentSize := int(float64(size) * Recent2QRatio)
+	if recentSize < 1 {
+		recentSize = 1
+	}
+	evictSize := int(float64(size) * Ghost2QRatio)
+	if evictSize < 1 {
+		evictSize = 1
+	}
+
+	recent, err := simplelru.NewLru[K, V](size, nil)
+	if err != nil {
+		return nil, err
+	}
+	frequent, err := simplelru.NewLru[K, V](size, nil)
+	if err != nil {
+		return nil, err
+	}
+	recentEvict, err := simplelru.NewLru[K, struct{}](evictSize, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &TwoQueueCache[K, V]{
+		size:        size,
+		recentSize:  recentSize,
+		recent:      recent,
+		frequent:    frequent,
+		recentEvict: recentEvict,
+		onEvictCb:   onEvict,
+	}
+	if onEvict != nil {
+		c.initEvictBuffer()
+	}
+	return c, nil
+}
+
+// initEvictBuffer initializes the key/valsEvicted fields of the cache.
+func (c *TwoQueueCache[K, V]) initEvictBuffer() {
+	c.keysEvicted = make([]K, 0, 16)
+	c.valsEvicted = make([]V, 0, 16)
+}
+
+// onEvicted buffers a key/val pair evicted from recent or frequent so it can
+// be reported to onEvictCb after the lock is released.
+func (c *TwoQueueCache[K, V]) onEvicted(key K, val V) {
+	if c.onEvictCb != nil {
+		c.keysEvicted = append(c.keysEvicted, key)
+		c.valsEvicted = append(c.valsEvicted, val)
+	}
+}
+
+// drainEvicted returns and resets the evicted key/val buffers. Callers must
+// hold c.rwm.
+func (c *TwoQueueCache[K, V]) drainEvicted() (keys []K, vals []V) {
+	if c.onEvictCb == nil || len(c.keysEvicted) == 0 {
+		return nil, nil
+	}
+	keys, vals = c.keysEvicted, c.valsEvicted
+	c.initEvictBuffer()
+	return keys, vals
+}
+
+// fireEvicted invokes onEvictCb for each drained key/val pair. Must be
+// called without c.rwm held.
+func (c *TwoQueueCache[K, V]) fireEvicted(keys []K, vals []V) {
+	for i := range keys {
+		c.onEvictCb(keys[i], vals[i])
+	}
+}
+
+// Get returns the value for the given key if it exists, promoting it to the
+// frequent list on a second access.
+func (c *TwoQueueCache[K, V]) Get(key K) (val V, ok bool) {
+	c.rwm.Lock()
+	defer c.rwm.Unlock()
+
+	if val, ok = c.frequent.Get(key); ok {
+		return val, ok
+	}
+	if val, ok = c.recent.Peek(key); ok {
+		c.recent.Remove(key)
+		c.frequent.Add(key, val)
+		return val, ok
+	}
+	return val, false
+}
+
+// Peek returns the value for the given key without promoting it or updating
+// its recentness.
+func (c *TwoQueueCache[K, V]) Peek(key K) (val V, ok bool) {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+
+	if val, ok = c.frequent.Peek(key); ok {
+		return val, ok
+	}
+	return c.recent.Peek(key)
+}
+
+// Contains returns true if the key exists in the cache, without updating
+// its recentness.
+func (c *TwoQueueCache[K, V]) Contains(key K) bool {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.frequent.Contains(key) || c.recent.Contains(key)
+}
+
+// Add adds a key-val pair to the cache, applying the 2Q admission policy and
+// calling the onEvict callback for every entry evicted to make room. Returns
+// true if an eviction occurred, matching golru.Cache.Add.
+func (c *TwoQueueCache[K, V]) Add(key K, val V) bool {
+	c.rwm.Lock()
+	evicted := c.add(key, val)
+	keys, vals := c.drainEvicted()
+	c.rwm.Unlock()
+	c.fireEvicted(keys, vals)
+	return evicted
+}
+
+// add applies the 2Q admission policy and reports whether an eviction
+// occurred. Callers must hold c.rwm.
+func (c *TwoQueueCache[K, V]) add(key K, val V) bool {
+	switch {
+	case c.frequent.Contains(key):
+		c.frequent.Add(key, val)
+		return false
+	case c.recent.Contains(key):
+		c.recent.Remove(key)
+		c.frequent.Add(key, val)
+		return false
+	case c.recentEvict.Contains(key):
+		evicted := c.ensureSpace(true)
+		c.recentEvict.Remove(key)
+		c.frequent.Add(key, val)
+		return evicted
+	default:
+		evicted := c.ensureSpace(false)
+		c.recent.Add(key, val)
+		return evicted
+	}
+}
+
+// ensureSpace evicts from recent or frequent until there's room for one more
+// entry, reporting whether an eviction occurred. recentEvicted reports
+// whether the entry being admitted came from the ghost list, which biases
+// eviction towards frequent so a repeat visitor isn't immediately bumped
+// back out. Callers must hold c.rwm.
+func (c *TwoQueueCache[K, V]) ensureSpace(recentEvicted bool) bool {
+	if c.recent.Len()+c.frequent.Len() < c.size {
+		return false
+	}
+	if c.recent.Len() > 0 && (c.recent.Len() > c.recentSize || (c.recent.Len() == c.recentSize && !recentEvicted)) {
+		k, v, ok := c.recent.RemoveOldest()
+		if ok {
+			c.recentEvict.Add(k, struct{}{})
+			c.onEvicted(k, v)
+		}
+		return ok
+	}
+	k, v, ok := c.frequent.RemoveOldest()
+	if ok {
+		c.onEvicted(k, v)
+	}
+	return ok
+}
+
+// Remove removes the key from the cache if it exists. Returns whether the
+// key was present.
+func (c *TwoQueueCache[K, V]) Remove(key K) bool {
+	c.rwm.Lock()
+	defer c.rwm.Unlock()
+
+	if c.frequent.Remove(key) {
+		return true
+	}
+	if c.recent.Remove(key) {
+		return true
+	}
+	c.recentEvict.Remove(key)
+	return false
+}
+
+// Keys returns a slice of the keys in the cache, from oldest to newest
+// within recent then frequent.
+func (c *TwoQueueCache[K, V]) Keys() []K {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+
+	keys := make([]K, 0, c.recent.Len()+c.frequent.Len())
+	keys = append(keys, c.recent.Keys()...)
+	keys = append(keys, c.frequent.Keys()...)
+	return keys
+}
+
+// Len returns the number of items in the cache.
+func (c *TwoQueueCache[K, V]) Len() int {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.recent.Len() + c.frequent.Len()
+}
+
+// Cap returns the capacity of the cache.
+func (c *TwoQueueCache[K, V]) Cap() int {
+	return c.size
+}
+
+// Purge is used to completely clear the cache.
+func (c *TwoQueueCache[K, V]) Purge() {
+	c.rwm.Lock()
+	defer c.rwm.Unlock()
+
+	c.recent.Purge()
+	c.frequent.Purge()
+	c.recentEvict.Purge()
+}
+
+// Resize resizes the cache to the given size, evicting entries as needed,
+// and returns the number of keys evicted.
+func (c *TwoQueueCache[K, V]) Resize(size int) int {
+	c.rwm.Lock()
+	diff := c.recent.Len() + c.frequent.Len() - size
+	if size < 0 {
+		size = 0
+	}
+	c.size = size
+	c.recentSize = int(float64(size) * Recent2QRatio)
+	if c.recentSize < 1 {
+		c.recentSize = 1
+	}
+
+	evicted := 0
+	for i := 0; i < diff; i++ {
+		if c.recent.Len() > c.recentSize || c.frequent.Len() == 0 {
+			k, v, ok := c.recent.RemoveOldest()
+			if ok {
+				c.recentEvict.Add(k, struct{}{})
+				c.onEvicted(k, v)
+				evicted++
+			}
+			continue
+		}
+		k, v, ok := c.frequent.RemoveOldest()
+		if ok {
+			c.onEvicted(k, v)
+			evicted++
+		}
+	}
+	keys, vals := c.drainEvicted()
+	c.rwm.Unlock()
+	c.fireEvicted(keys, vals)
+	return evicted
+}
@@ -0,0 +1,121 @@
+package go2q
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoQueueCache_RecentToFrequentPromotion(t *testing.T) {
+	cache, err := New2Q[int, int](8)
+	require.NoError(t, err)
+
+	cache.Add(1, 1)
+	require.True(t, cache.recent.Contains(1))
+	require.False(t, cache.frequent.Contains(1))
+
+	// a Get after the Add is the key's second access and promotes it from
+	// recent to frequent.
+	val, ok := cache.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 1, val)
+	require.False(t, cache.recent.Contains(1))
+	require.True(t, cache.frequent.Contains(1))
+}
+
+func TestTwoQueueCache_GhostListPromotesOnReentry(t *testing.T) {
+	cache, err := New2Q[int, int](4)
+	require.NoError(t, err)
+
+	// recentSize is 1 (4*0.25), so once the cache fills up, every further
+	// Add evicts the oldest recent entry into the ghost list.
+	for i := 0; i < 7; i++ {
+		cache.Add(i, i)
+	}
+	require.True(t, cache.recentEvict.Len() > 0)
+
+	ghostKey := cache.recentEvict.Keys()[0]
+	cache.Add(ghostKey, 100)
+	require.True(t, cache.frequent.Contains(ghostKey), "re-adding a ghost key should promote straight to frequent")
+}
+
+func TestTwoQueueCache_AddReportsEviction(t *testing.T) {
+	cache, err := New2Q[int, int](2)
+	require.NoError(t, err)
+
+	require.False(t, cache.Add(1, 1))
+	require.False(t, cache.Add(2, 2))
+	require.True(t, cache.Add(3, 3), "adding beyond capacity should report an eviction")
+}
+
+func TestTwoQueueCache_RemoveAndContains(t *testing.T) {
+	cache, err := New2Q[string, int](4)
+	require.NoError(t, err)
+
+	cache.Add("a", 1)
+	require.True(t, cache.Contains("a"))
+	require.True(t, cache.Remove("a"))
+	require.False(t, cache.Remove("a"))
+	require.False(t, cache.Contains("a"))
+}
+
+func TestTwoQueueCache_KeysAndLen(t *testing.T) {
+	cache, err := New2Q[int, int](8)
+	require.NoError(t, err)
+
+	cache.Add(1, 1)
+	cache.Add(2, 2)
+	cache.Get(1) // promote 1 to frequent
+
+	require.Equal(t, 2, cache.Len())
+	require.ElementsMatch(t, []int{1, 2}, cache.Keys())
+}
+
+func TestTwoQueueCache_PurgeAndResize(t *testing.T) {
+	var evicted []int
+	onEvict := func(key, _ int) {
+		evicted = append(evicted, key)
+	}
+	cache, err := New2QWithEvict[int, int](4, onEvict)
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		cache.Add(i, i)
+	}
+	cache.Purge()
+	require.Zero(t, cache.Len())
+
+	for i := 0; i < 8; i++ {
+		cache.Add(i, i)
+	}
+	n := cache.Resize(2)
+	require.Positive(t, n)
+	require.LessOrEqual(t, cache.Len(), 2)
+	require.NotEmpty(t, evicted)
+}
+
+// TestTwoQueueCache_ResizeNegative proves that a negative size is clamped to
+// 0 instead of sending the eviction loop into a spin, mirroring
+// simplelru.LruCache.Resize.
+func TestTwoQueueCache_ResizeNegative(t *testing.T) {
+	cache, err := New2Q[int, int](4)
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		cache.Add(i, i)
+	}
+
+	done := make(chan int, 1)
+	go func() {
+		done <- cache.Resize(-1)
+	}()
+
+	select {
+	case n := <-done:
+		require.Positive(t, n)
+		require.Zero(t, cache.Cap())
+	case <-time.After(2 * time.Second):
+		t.Fatal("deadlock detected: Resize did not return for a negative size")
+	}
+}
@@ -207,11 +207,25 @@ func (c *Cache[K, V]) RemoveOldest() (K, V, bool) {
 }
 
 // Get returns the value and true for the given key if it exists in the cache,
-// or nil and false otherwise.
+// or nil and false otherwise. Calls the callback function if an eviction
+// occurred.
 func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var k K
+	var v V
 	c.rwm.Lock()
-	defer c.rwm.Unlock()
-	return c.lru.Get(key)
+	val, ok := c.lru.Get(key)
+	evicted := c.onEvictCb != nil && len(c.keysEvicted) > 0
+	if evicted {
+		k = c.keysEvicted[0]
+		v = c.valsEvicted[0]
+		c.keysEvicted = c.keysEvicted[:0]
+		c.valsEvicted = c.valsEvicted[:0]
+	}
+	c.rwm.Unlock()
+	if evicted {
+		c.onEvictCb(k, v)
+	}
+	return val, ok
 }
 
 // Contains returns true if the key exists in the cache.
@@ -232,29 +246,28 @@ func (c *Cache[K, V]) Peek(key K) (V, bool) {
 // GetOldest returns the oldest entry.
 func (c *Cache[K, V]) GetOldest() (K, V, bool) {
 	c.rwm.RLock()
-	c.rwm.RUnlock()
+	defer c.rwm.RUnlock()
 	return c.lru.GetOldest()
-
 }
 
 // Keys returns a slice of the keys in the cache, from oldest to newest.
 func (c *Cache[K, V]) Keys() []K {
 	c.rwm.RLock()
-	c.rwm.RUnlock()
+	defer c.rwm.RUnlock()
 	return c.lru.Keys()
 }
 
 // Vals returns a slice of the values in the cache, from oldest to newest.
 func (c *Cache[K, V]) Vals() []V {
 	c.rwm.RLock()
-	c.rwm.RUnlock()
+	defer c.rwm.RUnlock()
 	return c.lru.Vals()
 }
 
 // Len returns the number of items in the cache.
 func (c *Cache[K, V]) Len() int {
 	c.rwm.RLock()
-	c.rwm.RUnlock()
+	defer c.rwm.RUnlock()
 	return c.lru.Len()
 }
 
@@ -0,0 +1,44 @@
+package golru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCache_ReentrantEvictCallback proves that none of the mutating Cache
+// methods deadlock when the eviction callback itself calls back into the
+// cache. The callback always re-adds key 0; when key 0 is the entry that was
+// just evicted, the re-add inserts it afresh and can trigger a nested
+// eviction of its own, which must not deadlock either.
+func TestCache_ReentrantEvictCallback(t *testing.T) {
+	var cache *Cache[int, int]
+	onEvict := func(key, val int) {
+		cache.Add(0, val)
+	}
+	var err error
+	cache, err = NewLruWithEvict[int, int](2, onEvict)
+	require.NoError(t, err)
+	cache.Add(0, 0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.Add(1, 1)
+		cache.Get(0)
+		cache.Add(2, 2)
+		cache.Remove(2)
+		cache.RemoveOldest()
+		cache.ContainsOrAdd(3, 3)
+		cache.PeekOrAdd(4, 4)
+		cache.Resize(1)
+		cache.Purge()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("deadlock detected: a mutating Cache method did not return")
+	}
+}
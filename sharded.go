@@ -0,0 +1,193 @@
+package golru
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"math"
+)
+
+// HasherFunc computes the shard-routing hash for a key.
+type HasherFunc[K comparable] func(key K) uint64
+
+// ShardedCache wraps a fixed number of independent Cache instances, routing
+// each key to a single shard by hash. Each shard has its own lock, so
+// concurrent access to different shards never contends on the same mutex,
+// unlike a single Cache under heavy contention.
+type ShardedCache[K comparable, V any] struct {
+	shards []*Cache[K, V]
+	hasher HasherFunc[K]
+	seed   maphash.Seed
+}
+
+// NewShardedLru creates a sharded LRU of totalSize split evenly across the
+// given number of shards, using the default key hasher.
+func NewShardedLru[K comparable, V any](totalSize, shards int, onEvict func(key K, val V)) (*ShardedCache[K, V], error) {
+	return NewShardedLruWithHasher[K, V](totalSize, shards, onEvict, nil)
+}
+
+// NewShardedLruWithHasher is like NewShardedLru but allows supplying a
+// custom HasherFunc for shard routing, e.g. a cheaper hash for a known key
+// type.
+func NewShardedLruWithHasher[K comparable, V any](totalSize, shards int, onEvict func(key K, val V), hasher HasherFunc[K]) (*ShardedCache[K, V], error) {
+	if shards <= 0 {
+		return nil, errors.New("shards must be greater than 0")
+	}
+	shardSize := int(math.Ceil(float64(totalSize) / float64(shards)))
+
+	sc := &ShardedCache[K, V]{
+		shards: make([]*Cache[K, V], shards),
+		hasher: hasher,
+		seed:   maphash.MakeSeed(),
+	}
+	if sc.hasher == nil {
+		sc.hasher = sc.defaultHash
+	}
+	for i := range sc.shards {
+		c, err := NewLruWithEvict[K, V](shardSize, onEvict)
+		if err != nil {
+			return nil, err
+		}
+		sc.shards[i] = c
+	}
+	return sc, nil
+}
+
+// defaultHash hashes key for shard routing. Common key types are fed to the
+// hash directly to avoid the reflection and allocation in fmt.Sprint; any
+// other comparable K falls back to its string representation, which works
+// for any K without relying on unsafe or reflect-based field walking.
+func (sc *ShardedCache[K, V]) defaultHash(key K) uint64 {
+	var h maphash.Hash
+	h.SetSeed(sc.seed)
+	switch k := any(key).(type) {
+	case string:
+		_, _ = h.WriteString(k)
+	case []byte:
+		_, _ = h.Write(k)
+	case int:
+		writeUint64(&h, uint64(k))
+	case int8:
+		_ = h.WriteByte(byte(k))
+	case int16:
+		writeUint64(&h, uint64(k))
+	case int32:
+		writeUint64(&h, uint64(k))
+	case int64:
+		writeUint64(&h, uint64(k))
+	case uint:
+		writeUint64(&h, uint64(k))
+	case uint8:
+		_ = h.WriteByte(k)
+	case uint16:
+		writeUint64(&h, uint64(k))
+	case uint32:
+		writeUint64(&h, uint64(k))
+	case uint64:
+		writeUint64(&h, k)
+	default:
+		_, _ = h.WriteString(fmt.Sprint(key))
+	}
+	return h.Sum64()
+}
+
+// writeUint64 feeds v's raw bytes into h, avoiding the allocation that
+// fmt.Sprint(v) plus WriteString would incur.
+func writeUint64(h *maphash.Hash, v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	_, _ = h.Write(buf[:])
+}
+
+// shardFor returns the shard responsible for key.
+func (sc *ShardedCache[K, V]) shardFor(key K) *Cache[K, V] {
+	idx := sc.hasher(key) % uint64(len(sc.shards))
+	return sc.shards[idx]
+}
+
+// Add adds a new key-value pair to the owning shard and calls the onEvict
+// callback if an element was evicted.
+func (sc *ShardedCache[K, V]) Add(key K, val V) bool {
+	return sc.shardFor(key).Add(key, val)
+}
+
+// Get returns the value and true for the given key if it exists in the
+// cache, or nil and false otherwise.
+func (sc *ShardedCache[K, V]) Get(key K) (V, bool) {
+	return sc.shardFor(key).Get(key)
+}
+
+// Peek returns the value for the given key without updating the recentness
+// of the key.
+func (sc *ShardedCache[K, V]) Peek(key K) (V, bool) {
+	return sc.shardFor(key).Peek(key)
+}
+
+// Contains returns true if the key exists in the cache.
+func (sc *ShardedCache[K, V]) Contains(key K) bool {
+	return sc.shardFor(key).Contains(key)
+}
+
+// Remove removes the key from the cache if it exists. Returns whether the
+// key was present. Calls the callback function if an eviction occurred.
+func (sc *ShardedCache[K, V]) Remove(key K) bool {
+	return sc.shardFor(key).Remove(key)
+}
+
+// Len returns the number of items across all shards.
+func (sc *ShardedCache[K, V]) Len() int {
+	total := 0
+	for _, shard := range sc.shards {
+		total += shard.Len()
+	}
+	return total
+}
+
+// Cap returns the aggregate capacity across all shards.
+func (sc *ShardedCache[K, V]) Cap() int {
+	total := 0
+	for _, shard := range sc.shards {
+		total += shard.Cap()
+	}
+	return total
+}
+
+// Keys returns a slice of the keys across all shards. Order is per-shard,
+// oldest to newest, and not meaningful across shard boundaries.
+func (sc *ShardedCache[K, V]) Keys() []K {
+	keys := make([]K, 0, sc.Len())
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Vals returns a slice of the values across all shards. Order is per-shard,
+// oldest to newest, and not meaningful across shard boundaries.
+func (sc *ShardedCache[K, V]) Vals() []V {
+	vals := make([]V, 0, sc.Len())
+	for _, shard := range sc.shards {
+		vals = append(vals, shard.Vals()...)
+	}
+	return vals
+}
+
+// Purge is used to completely clear every shard.
+func (sc *ShardedCache[K, V]) Purge() {
+	for _, shard := range sc.shards {
+		shard.Purge()
+	}
+}
+
+// Resize resizes every shard to ceil(totalSize/shards), so the aggregate
+// capacity is totalSize rounded up to a multiple of the shard count, and
+// returns the total number of keys evicted if any.
+func (sc *ShardedCache[K, V]) Resize(totalSize int) int {
+	shardSize := int(math.Ceil(float64(totalSize) / float64(len(sc.shards))))
+	evicted := 0
+	for _, shard := range sc.shards {
+		evicted += shard.Resize(shardSize)
+	}
+	return evicted
+}
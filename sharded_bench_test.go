@@ -0,0 +1,41 @@
+package golru
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// mixedWorkload runs b.N Add/Get operations spread across b's parallelism,
+// writing on every 10th operation and reading otherwise.
+func mixedWorkload(b *testing.B, add func(key string, val int) bool, get func(key string) (int, bool)) {
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var i int64
+		for pb.Next() {
+			n := atomic.AddInt64(&i, 1)
+			key := strconv.FormatInt(n%1000, 10)
+			if n%10 == 0 {
+				add(key, int(n))
+			} else {
+				get(key)
+			}
+		}
+	})
+}
+
+func BenchmarkCache_Mixed(b *testing.B) {
+	c, err := NewLru[string, int](1000)
+	if err != nil {
+		b.Fatal(err)
+	}
+	mixedWorkload(b, c.Add, c.Get)
+}
+
+func BenchmarkShardedCache_Mixed(b *testing.B) {
+	sc, err := NewShardedLru[string, int](1000, 16, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	mixedWorkload(b, sc.Add, sc.Get)
+}
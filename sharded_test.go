@@ -0,0 +1,103 @@
+package golru
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedCache_AddGetRemove(t *testing.T) {
+	sc, err := NewShardedLru[string, int](100, 4, nil)
+	require.NoError(t, err)
+
+	sc.Add("a", 1)
+	sc.Add("b", 2)
+
+	val, ok := sc.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, val)
+
+	require.True(t, sc.Contains("b"))
+	require.True(t, sc.Remove("b"))
+	require.False(t, sc.Contains("b"))
+}
+
+func TestShardedCache_RoutingIsStable(t *testing.T) {
+	sc, err := NewShardedLru[string, int](100, 4, nil)
+	require.NoError(t, err)
+
+	key := "stable-key"
+	first := sc.shardFor(key)
+	for i := 0; i < 10; i++ {
+		require.Same(t, first, sc.shardFor(key), "the same key must always route to the same shard")
+	}
+}
+
+func TestShardedCache_RoutingIsStableForIntKeys(t *testing.T) {
+	// Exercises defaultHash's fast path for int keys, not just its
+	// fmt.Sprint fallback for uncommon types.
+	sc, err := NewShardedLru[int, int](100, 4, nil)
+	require.NoError(t, err)
+
+	key := 42
+	first := sc.shardFor(key)
+	for i := 0; i < 10; i++ {
+		require.Same(t, first, sc.shardFor(key), "the same key must always route to the same shard")
+	}
+}
+
+func TestShardedCache_LenKeysAndCapAggregate(t *testing.T) {
+	// Sized generously relative to the 8 keys added so an uneven hash
+	// distribution across shards can't evict anything out from under the
+	// assertions below.
+	sc, err := NewShardedLru[int, int](80, 4, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		sc.Add(i, i)
+	}
+	require.Equal(t, 80, sc.Cap())
+	require.Equal(t, 8, sc.Len())
+	require.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, sc.Keys())
+
+	vals := sc.Vals()
+	require.Len(t, vals, 8)
+}
+
+func TestShardedCache_PurgeAndResize(t *testing.T) {
+	var evicted int
+	onEvict := func(_ int, _ int) {
+		evicted++
+	}
+	sc, err := NewShardedLru[int, int](8, 4, onEvict)
+	require.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		sc.Add(i, i)
+	}
+	sc.Purge()
+	require.Zero(t, sc.Len())
+
+	for i := 0; i < 16; i++ {
+		sc.Add(i, i)
+	}
+	n := sc.Resize(4)
+	require.Positive(t, n)
+	require.LessOrEqual(t, sc.Len(), 4)
+	require.Positive(t, evicted)
+}
+
+func TestShardedCache_CustomHasherRoutesEverythingToOneShard(t *testing.T) {
+	always0 := func(string) uint64 { return 0 }
+	sc, err := NewShardedLruWithHasher[string, int](100, 4, nil, always0)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		sc.Add(strconv.Itoa(i), i)
+	}
+	require.Equal(t, 10, sc.shards[0].Len())
+	for _, shard := range sc.shards[1:] {
+		require.Zero(t, shard.Len())
+	}
+}
@@ -3,7 +3,7 @@ package simplelru
 import (
 	"errors"
 
-	"github.com/srivastavcodes/go-lru/dll"
+	"github.com/srivastavcodes/gocache/dll"
 )
 
 // EvictCallback is used to get a callback when a cache entry is evicted.
@@ -11,10 +11,13 @@ type EvictCallback[K comparable, V any] func(key K, value V)
 
 // LruCache is a simple LRU items implementation of fixed size and not thread-safe.
 type LruCache[K comparable, V any] struct {
-	size      int
-	evictList *dll.LruList[K, V]
-	items     map[K]*dll.Entry[K, V]
-	onEvict   EvictCallback[K, V]
+	size int
+	// generation is bumped on every Purge so entries from before the purge
+	// can be told apart from entries added after it without walking them.
+	generation int64
+	evictList  *dll.LruList[K, V]
+	items      map[K]*dll.Entry[K, V]
+	onEvict    EvictCallback[K, V]
 }
 
 // NewLru initializes a LruCache of the given size.
@@ -29,25 +32,30 @@ func NewLru[K comparable, V any](size int, onEvict EvictCallback[K, V]) (*LruCac
 	}, nil
 }
 
-// Purge will completely clear the cache.
+// Purge will completely clear the cache. Rather than walking and deleting
+// every map entry, it bumps the generation counter and swaps in fresh
+// storage, making the common case (no eviction callback) O(1) plus GC.
 func (lru *LruCache[K, V]) Purge() {
+	old := lru.items
+	lru.generation++
 	lru.evictList.Init()
-	for k, v := range lru.items {
-		if lru.onEvict != nil {
-			lru.onEvict(k, v.Val)
+	lru.items = make(map[K]*dll.Entry[K, V])
+	if lru.onEvict != nil {
+		for _, entry := range old {
+			lru.onEvict(entry.Key, entry.Val)
 		}
-		delete(lru.items, k)
 	}
 }
 
 // Add adds a new key-value pair to the cache. Returns true if an eviction occurred.
 func (lru *LruCache[K, V]) Add(key K, val V) (evict bool) {
-	if entry, ok := lru.items[key]; ok {
+	if entry, ok := lru.items[key]; ok && entry.Generation == lru.generation {
 		lru.evictList.MoveToFront(entry)
 		entry.Val = val
 		return
 	}
 	entry := lru.evictList.PushFront(key, val)
+	entry.Generation = lru.generation
 	lru.items[key] = entry
 	// check size is exceeded or not?
 	evict = lru.evictList.Length() > lru.size
@@ -61,8 +69,8 @@ func (lru *LruCache[K, V]) Add(key K, val V) (evict bool) {
 // the recentness of the key.
 func (lru *LruCache[K, V]) Get(key K) (val V, ok bool) {
 	entry, ok := lru.items[key]
-	if !ok {
-		return
+	if !ok || entry.Generation != lru.generation {
+		return val, false
 	}
 	lru.evictList.MoveToFront(entry)
 	return entry.Val, true
@@ -78,15 +86,15 @@ func (lru *LruCache[K, V]) GetOldest() (key K, val V, ok bool) {
 
 // Contains returns true if the key exists in the cache.
 func (lru *LruCache[K, V]) Contains(key K) (ok bool) {
-	_, ok = lru.items[key]
-	return ok
+	entry, ok := lru.items[key]
+	return ok && entry.Generation == lru.generation
 }
 
 // Peek returns the value for the given key and true if exists.
 func (lru *LruCache[K, V]) Peek(key K) (val V, ok bool) {
 	entry, ok := lru.items[key]
-	if !ok {
-		return
+	if !ok || entry.Generation != lru.generation {
+		return val, false
 	}
 	return entry.Val, true
 }
@@ -145,7 +153,7 @@ func (lru *LruCache[K, V]) Resize(size int) (evictCount int) {
 
 // Remove removes the key from the cache.
 func (lru *LruCache[K, V]) Remove(key K) (present bool) {
-	if entry, present := lru.items[key]; present {
+	if entry, present := lru.items[key]; present && entry.Generation == lru.generation {
 		lru.removeElement(entry)
 		return present
 	}
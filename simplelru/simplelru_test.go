@@ -58,3 +58,22 @@ func TestLru(t *testing.T) {
 	_, ok := lru.Get(0)
 	require.False(t, ok, "should not be in cache")
 }
+
+func TestLruPurgeGeneration(t *testing.T) {
+	lru, err := NewLru[int, int](2, nil)
+	require.NoError(t, err)
+
+	lru.Add(1, 1)
+	lru.Add(2, 2)
+	lru.Purge()
+	require.Zero(t, lru.Len())
+
+	_, ok := lru.Get(1)
+	require.False(t, ok, "pre-purge entry should not resurface")
+
+	lru.Add(1, 100)
+	val, ok := lru.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 100, val)
+	require.Equal(t, 1, lru.Len())
+}